@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oncom96/solana-balance-api/internal/config"
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+)
+
+func newAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "Manage API keys",
+	}
+
+	var tier string
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new API key",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := mustKeysRepo(loadConfig(cmd.Flags()))
+			doc, err := keys.Create(mongostore.APIKeyTier(tier))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ created key %s (tier=%s, rpmLimit=%d, dailyQuota=%d)\n", doc.Key, doc.Tier, doc.RPMLimit, doc.DailyQuota)
+			return nil
+		},
+	}
+	create.Flags().StringVar(&tier, "tier", string(mongostore.TierFree), "tier for the new key (free|pro)")
+	cmd.AddCommand(create)
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List API keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := mustKeysRepo(loadConfig(cmd.Flags()))
+			docs, err := keys.List(context.Background())
+			if err != nil {
+				return err
+			}
+			for _, d := range docs {
+				fmt.Printf("%s\tactive=%v\ttier=%s\tusage=%d/%d\n", d.Key, d.Active, d.Tier, d.Usage.Count, d.DailyQuota)
+			}
+			return nil
+		},
+	}
+	cmd.AddCommand(list)
+
+	revoke := &cobra.Command{
+		Use:   "revoke <key>",
+		Short: "Deactivate an API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := mustKeysRepo(loadConfig(cmd.Flags()))
+			active := false
+			if _, err := keys.Patch(args[0], &active, "", false); err != nil {
+				return err
+			}
+			fmt.Println("✅ revoked", args[0])
+			return nil
+		},
+	}
+	cmd.AddCommand(revoke)
+
+	return cmd
+}
+
+func mustKeysRepo(cfg *config.Config) *mongostore.KeysRepo {
+	store, err := mongostore.Connect(cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+	return mongostore.NewKeysRepo(store)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/spf13/cobra"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+	solanasvc "github.com/oncom96/solana-balance-api/internal/solana"
+)
+
+// newHealthcheckCmd probes the RPC pool and MongoDB and exits nonzero on
+// failure, so it can be wired up as a Docker HEALTHCHECK.
+func newHealthcheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "healthcheck",
+		Short: "Probe Solana RPC and MongoDB connectivity",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := loadConfig(cmd.Flags())
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if len(cfg.SolanaRPCURLs) == 0 {
+				fmt.Fprintln(os.Stderr, "❌ no Solana RPC endpoints configured")
+				os.Exit(1)
+			}
+			pool := solanasvc.NewPool(cfg.SolanaRPCURLs, solanasvc.PolicyFromString(cfg.RPCPolicy))
+			if _, err := pool.Primary().GetSlot(ctx, rpc.CommitmentFinalized); err != nil {
+				fmt.Fprintln(os.Stderr, "❌ Solana RPC unreachable:", err)
+				os.Exit(1)
+			}
+
+			store, err := mongostore.Connect(cfg.MongoURI, cfg.MongoDB)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "❌ MongoDB unreachable:", err)
+				os.Exit(1)
+			}
+			if err := store.Client.Ping(ctx, nil); err != nil {
+				fmt.Fprintln(os.Stderr, "❌ MongoDB ping failed:", err)
+				os.Exit(1)
+			}
+
+			fmt.Println("✅ healthy")
+			return nil
+		},
+	}
+}
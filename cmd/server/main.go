@@ -0,0 +1,49 @@
+// Command server is the solana-balance-api entrypoint: a Cobra CLI wrapping
+// the `serve` subcommand (the original always-on behavior) plus operational
+// subcommands (api key management, Mongo migrations, a Docker-friendly
+// healthcheck).
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/oncom96/solana-balance-api/internal/config"
+)
+
+var configPath string
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, "⚠️ No .env file found, using system env")
+	}
+
+	root := &cobra.Command{
+		Use:   "server",
+		Short: "solana-balance-api server and operational CLI",
+	}
+	root.PersistentFlags().StringVar(&configPath, "config", "", "path to a YAML config file")
+
+	root.AddCommand(newServeCmd())
+	root.AddCommand(newAPIKeyCmd())
+	root.AddCommand(newMigrateCmd())
+	root.AddCommand(newHealthcheckCmd())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func loadConfig(fs *pflag.FlagSet) *config.Config {
+	cfg, err := config.Load(configPath, fs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌ failed to load config:", err)
+		os.Exit(1)
+	}
+	return cfg
+}
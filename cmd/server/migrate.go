@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+)
+
+func newMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Create/update MongoDB indexes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := loadConfig(cmd.Flags())
+
+			store, err := mongostore.Connect(cfg.MongoURI, cfg.MongoDB)
+			if err != nil {
+				return err
+			}
+
+			if err := store.EnsureIndexes(context.Background()); err != nil {
+				fmt.Fprintln(os.Stderr, "❌", err)
+				os.Exit(1)
+			}
+
+			fmt.Println("✅ indexes up to date")
+			return nil
+		},
+	}
+}
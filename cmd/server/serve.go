@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oncom96/solana-balance-api/internal/api"
+	"github.com/oncom96/solana-balance-api/internal/cache"
+	"github.com/oncom96/solana-balance-api/internal/config"
+	"github.com/oncom96/solana-balance-api/internal/middleware"
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+	solanasvc "github.com/oncom96/solana-balance-api/internal/solana"
+)
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP/WebSocket API server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(loadConfig(cmd.Flags()))
+		},
+	}
+	return cmd
+}
+
+func runServe(cfg *config.Config) error {
+	store, err := mongostore.Connect(cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		return err
+	}
+
+	keys := mongostore.NewKeysRepo(store)
+	watches := mongostore.NewWatchesRepo(store)
+
+	if cfg.DefaultAPIKey != "" && !keys.Exists(cfg.DefaultAPIKey) {
+		if err := keys.InsertDefault(cfg.DefaultAPIKey); err != nil {
+			return fmt.Errorf("failed to insert default API key: %w", err)
+		}
+		log.Println("✅ Default API key inserted:", cfg.DefaultAPIKey)
+	}
+
+	if len(cfg.SolanaRPCURLs) == 0 {
+		return fmt.Errorf("no Solana RPC endpoints configured (set SOLANA_RPC_URLS or SOLANA_RPC_URL)")
+	}
+	pool := solanasvc.NewPool(cfg.SolanaRPCURLs, solanasvc.PolicyFromString(cfg.RPCPolicy))
+
+	probeCtx, cancelProbing := context.WithCancel(context.Background())
+	defer cancelProbing()
+	pool.StartProbing(probeCtx)
+
+	balanceCache, err := cache.New(cache.Config{
+		Backend:           cache.Backend(cfg.CacheBackend),
+		DefaultExpiration: cfg.BalanceCacheTTL,
+		CleanupInterval:   2 * cfg.BalanceCacheTTL,
+		RedisAddr:         cfg.RedisAddr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build cache: %w", err)
+	}
+
+	balance := solanasvc.NewBalanceService(pool, balanceCache, cfg.BalanceCacheTTL)
+	watcher := solanasvc.NewPaymentWatcher(pool, watches)
+	if err := watcher.Resume(); err != nil {
+		return fmt.Errorf("failed to resume pending payment watches: %w", err)
+	}
+
+	if cfg.SolanaWSURL == "" {
+		return fmt.Errorf("SOLANA_WS_URL not set")
+	}
+	subManager := solanasvc.NewSubscriptionManager(cfg.SolanaWSURL, balance)
+
+	r := api.NewRouter(api.Deps{
+		Pool:       pool,
+		Balance:    balance,
+		Watcher:    watcher,
+		SubManager: subManager,
+		Keys:       keys,
+		Watches:    watches,
+		Alerter:    middleware.DiscordAlerter{WebhookURL: cfg.DiscordWebhookURL},
+		AdminKey:   cfg.AdminAPIKey,
+		Limiter:    middleware.NewKeyLimiter(),
+	})
+
+	log.Println("✅ Connected to MongoDB!")
+	return r.Run(":" + cfg.Port)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+)
+
+func createAPIKeyHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Tier mongostore.APIKeyTier `json:"tier"`
+		}
+		_ = c.ShouldBindJSON(&req)
+		if req.Tier == "" {
+			req.Tier = mongostore.TierFree
+		}
+		if _, known := mongostore.DefaultLimitsByTier[req.Tier]; !known {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown tier"})
+			return
+		}
+
+		doc, err := deps.Keys.Create(req.Tier)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+			return
+		}
+
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+func patchAPIKeyHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.Param("key")
+
+		var req struct {
+			Active *bool                 `json:"active"`
+			Tier   mongostore.APIKeyTier `json:"tier"`
+			Rotate bool                  `json:"rotate"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if req.Tier != "" {
+			if _, known := mongostore.DefaultLimitsByTier[req.Tier]; !known {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown tier"})
+				return
+			}
+		}
+
+		newKey, err := deps.Keys.Patch(key, req.Active, req.Tier, req.Rotate)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"key": newKey})
+	}
+}
+
+func getAPIKeyUsageHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, err := deps.Keys.Fetch(c.Param("key"))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"key":        doc.Key,
+			"tier":       doc.Tier,
+			"rpmLimit":   doc.RPMLimit,
+			"dailyQuota": doc.DailyQuota,
+			"usage":      doc.Usage,
+		})
+	}
+}
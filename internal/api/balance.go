@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gin-gonic/gin"
+)
+
+func getBalanceHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Wallets []string `json:"wallets"`
+			Mints   []string `json:"mints"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil || len(req.Wallets) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		results := []gin.H{}
+		for _, w := range req.Wallets {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			bal, err := deps.Balance.GetBalance(ctx, w)
+			if err != nil {
+				results = append(results, gin.H{
+					"wallet":  w,
+					"error":   err.Error(),
+					"balance": 0,
+				})
+				continue
+			}
+
+			entry := gin.H{
+				"wallet":  w,
+				"balance": bal,
+			}
+
+			if len(req.Mints) > 0 {
+				tokens, err := deps.Balance.GetTokenBalances(ctx, w, req.Mints)
+				if err != nil {
+					entry["tokensError"] = err.Error()
+				} else {
+					entry["tokens"] = tokens
+				}
+			}
+
+			results = append(results, entry)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"balances": results})
+	}
+}
+
+func tokenSupplyHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mint := c.Param("mint")
+		if _, err := solana.PublicKeyFromBase58(mint); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mint address"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := deps.Balance.GetTokenSupply(ctx, mint)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"mint":     mint,
+			"amount":   resp.Value.Amount,
+			"decimals": resp.Value.Decimals,
+			"uiAmount": resp.Value.UiAmountString,
+		})
+	}
+}
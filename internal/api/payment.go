@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+	solanasvc "github.com/oncom96/solana-balance-api/internal/solana"
+)
+
+func watchPaymentHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Wallet           string    `json:"wallet"`
+			ExpectedLamports uint64    `json:"expectedLamports"`
+			Memo             string    `json:"memo"`
+			ExpiresAt        time.Time `json:"expiresAt"`
+			CallbackURL      string    `json:"callbackURL"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil || req.Wallet == "" || req.CallbackURL == "" || req.ExpectedLamports == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if _, err := solana.PublicKeyFromBase58(req.Wallet); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wallet address"})
+			return
+		}
+		if err := solanasvc.ValidateCallbackURL(req.CallbackURL); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.ExpiresAt.IsZero() {
+			req.ExpiresAt = time.Now().Add(1 * time.Hour)
+		}
+
+		watch := &mongostore.PaymentWatch{
+			Wallet:           req.Wallet,
+			ExpectedLamports: req.ExpectedLamports,
+			Memo:             req.Memo,
+			CallbackURL:      req.CallbackURL,
+			ExpiresAt:        req.ExpiresAt,
+			Status:           "pending",
+			APIKey:           c.GetHeader("x-api-key"),
+			CreatedAt:        time.Now(),
+		}
+
+		if err := deps.Watcher.Start(watch); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register watch"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"id": watch.ID.Hex(), "status": watch.Status})
+	}
+}
+
+func getPaymentWatchHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid watch id"})
+			return
+		}
+
+		watch, err := deps.Watches.Get(id)
+		if err != nil || watch.APIKey != c.GetHeader("x-api-key") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Watch not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, watch)
+	}
+}
@@ -0,0 +1,61 @@
+// Package api registers every HTTP/WebSocket route this service exposes and
+// wires them to the injected domain services, instead of reading
+// package-level globals the way main.go used to.
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/oncom96/solana-balance-api/internal/middleware"
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+	solanasvc "github.com/oncom96/solana-balance-api/internal/solana"
+)
+
+// Deps are the services and repositories route handlers need.
+type Deps struct {
+	Pool       *solanasvc.Pool
+	Balance    *solanasvc.BalanceService
+	Watcher    *solanasvc.PaymentWatcher
+	SubManager *solanasvc.SubscriptionManager
+	Keys       *mongostore.KeysRepo
+	Watches    *mongostore.WatchesRepo
+	Alerter    middleware.DiscordAlerter
+	AdminKey   string
+	Limiter    *middleware.KeyLimiter
+}
+
+// NewRouter builds the gin engine with every middleware and route attached.
+func NewRouter(deps Deps) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Logger())
+	r.Use(middleware.RecoveryWithDiscord(deps.Alerter))
+
+	// Registered outside the auth-middleware group: the websocket handshake
+	// needs to accept the API key via query param too, which subscribeHandler
+	// checks for itself.
+	r.GET("/api/subscribe", subscribeHandler(deps))
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	admin := r.Group("/admin", middleware.AdminAuthMiddleware(deps.AdminKey))
+	{
+		admin.POST("/api-keys", createAPIKeyHandler(deps))
+		admin.PATCH("/api-keys/:key", patchAPIKeyHandler(deps))
+		admin.GET("/api-keys/:key/usage", getAPIKeyUsageHandler(deps))
+	}
+	r.GET("/api/rpc-status", middleware.AdminAuthMiddleware(deps.AdminKey), deps.Pool.StatusHandler())
+
+	api := r.Group("/api", middleware.APIKeyAuthMiddleware(deps.Keys), middleware.KeyedRateLimitMiddleware(deps.Keys, deps.Limiter))
+	{
+		api.POST("/get-balance", getBalanceHandler(deps))
+		api.GET("/token-supply/:mint", tokenSupplyHandler(deps))
+		api.POST("/watch-payment", watchPaymentHandler(deps))
+		api.GET("/watch-payment/:id", getPaymentWatchHandler(deps))
+
+		api.GET("/panic", func(c *gin.Context) {
+			panic("Forced panic for testing Discord webhook!")
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/oncom96/solana-balance-api/internal/middleware"
+	solanasvc "github.com/oncom96/solana-balance-api/internal/solana"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// subscribeHandler upgrades the connection and streams Update messages for
+// every wallet the client asks to watch in its initial {"wallets": []}
+// frame.
+func subscribeHandler(deps Deps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader("x-api-key")
+		if apiKey == "" {
+			apiKey = c.Query("apiKey")
+		}
+		if apiKey == "" || !middleware.ValidateAPIKey(deps.Keys, apiKey) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing API key"})
+			return
+		}
+
+		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			log.Println("⚠️ websocket upgrade failed:", err)
+			return
+		}
+		defer conn.Close()
+
+		var req struct {
+			Wallets []string `json:"wallets"`
+		}
+		if err := conn.ReadJSON(&req); err != nil || len(req.Wallets) == 0 {
+			conn.WriteJSON(gin.H{"error": "expected {\"wallets\": [...]} as the first message"})
+			return
+		}
+
+		var wg sync.WaitGroup
+		var writeMu sync.Mutex
+		done := make(chan struct{})
+
+		for _, wallet := range req.Wallets {
+			ch, unsubscribe := deps.SubManager.Subscribe(wallet)
+			defer unsubscribe()
+
+			wg.Add(1)
+			go func(ch chan solanasvc.Update) {
+				defer wg.Done()
+				for {
+					select {
+					case upd, ok := <-ch:
+						if !ok {
+							return
+						}
+						writeMu.Lock()
+						err := conn.WriteJSON(upd)
+						writeMu.Unlock()
+						if err != nil {
+							return
+						}
+					case <-done:
+						return
+					}
+				}
+			}(ch)
+		}
+
+		// Block until the client disconnects; any read error (including a
+		// clean close) tears down all fan-out goroutines for this connection.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(done)
+				break
+			}
+		}
+
+		wg.Wait()
+	}
+}
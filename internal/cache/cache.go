@@ -0,0 +1,41 @@
+// Package cache defines the caching interface the balance service depends
+// on, with an in-process go-cache backend (the default) and an optional
+// Redis backend for deployments running more than one instance.
+package cache
+
+import "time"
+
+// Cache is the minimal interface the rest of the service needs: TTL'd
+// key-value storage keyed by string, values handed back as interface{} the
+// way the original package-level go-cache usage did.
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration)
+	Delete(key string)
+}
+
+// Backend selects which Cache implementation New returns.
+type Backend string
+
+const (
+	BackendMemory Backend = "memory"
+	BackendRedis  Backend = "redis"
+)
+
+// Config configures the cache backend.
+type Config struct {
+	Backend           Backend
+	DefaultExpiration time.Duration
+	CleanupInterval   time.Duration
+	RedisAddr         string
+}
+
+// New builds the configured Cache backend.
+func New(cfg Config) (Cache, error) {
+	switch cfg.Backend {
+	case BackendRedis:
+		return newRedisCache(cfg.RedisAddr, cfg.DefaultExpiration)
+	default:
+		return newMemoryCache(cfg.DefaultExpiration, cfg.CleanupInterval), nil
+	}
+}
@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+)
+
+// memoryCache wraps go-cache, the backend this service used before it had a
+// Redis option.
+type memoryCache struct {
+	c *gocache.Cache
+}
+
+func newMemoryCache(defaultExpiration, cleanupInterval time.Duration) Cache {
+	return &memoryCache{c: gocache.New(defaultExpiration, cleanupInterval)}
+}
+
+func (m *memoryCache) Get(key string) (interface{}, bool) {
+	return m.c.Get(key)
+}
+
+func (m *memoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	m.c.Set(key, value, ttl)
+}
+
+func (m *memoryCache) Delete(key string) {
+	m.c.Delete(key)
+}
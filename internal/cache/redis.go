@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCache stores gob-encoded values so it can hold the same interface{}
+// values (uint64 balances, TokenBalance structs) the memory backend does.
+// Callers that put custom struct types in the cache must gob.Register them
+// once at init time, the same way encoding/gob requires for any interface
+// value.
+type redisCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func newRedisCache(addr string, defaultExpiration time.Duration) (Cache, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	return &redisCache{client: client, ttl: defaultExpiration}, nil
+}
+
+func (r *redisCache) Get(key string) (interface{}, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	raw, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var value interface{}
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (r *redisCache) Set(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = r.ttl
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	r.client.Set(ctx, key, buf.Bytes(), ttl)
+}
+
+func (r *redisCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	r.client.Del(ctx, key)
+}
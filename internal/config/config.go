@@ -0,0 +1,113 @@
+// Package config loads the server's configuration from env vars, flags and
+// an optional YAML file into a single struct, so every other package
+// receives its settings by injection instead of reading os.Getenv directly.
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Config is the fully-resolved configuration for the server. Every field has
+// an env var fallback (see Load) so `serve` keeps working with no config
+// file at all.
+type Config struct {
+	Port string `mapstructure:"port"`
+
+	MongoURI string `mapstructure:"mongo_uri"`
+	MongoDB  string `mapstructure:"mongo_db"`
+
+	SolanaRPCURLs []string `mapstructure:"solana_rpc_urls"`
+	SolanaWSURL   string   `mapstructure:"solana_ws_url"`
+	RPCPolicy     string   `mapstructure:"rpc_policy"`
+
+	CacheBackend string `mapstructure:"cache_backend"` // "memory" or "redis"
+	RedisAddr    string `mapstructure:"redis_addr"`
+
+	DefaultAPIKey     string `mapstructure:"default_api_key"`
+	AdminAPIKey       string `mapstructure:"admin_api_key"`
+	DiscordWebhookURL string `mapstructure:"discord_webhook_url"`
+
+	BalanceCacheTTL time.Duration `mapstructure:"balance_cache_ttl"`
+}
+
+// Load reads configuration from (in increasing priority) a YAML file at
+// configPath (if non-empty and present), environment variables, and CLI
+// flags already bound to fs.
+func Load(configPath string, fs *pflag.FlagSet) (*Config, error) {
+	v := viper.New()
+
+	v.SetDefault("port", "8080")
+	v.SetDefault("cache_backend", "memory")
+	v.SetDefault("rpc_policy", "primary-failover")
+	v.SetDefault("balance_cache_ttl", 10*time.Second)
+
+	v.SetEnvPrefix("")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	bindEnv(v, "mongo_uri", "DEV_DB_URL")
+	bindEnv(v, "mongo_db", "MONGO_DB")
+	bindEnv(v, "solana_ws_url", "SOLANA_WS_URL")
+	bindEnv(v, "default_api_key", "DEFAULT_API_KEY")
+	bindEnv(v, "admin_api_key", "ADMIN_API_KEY")
+	bindEnv(v, "discord_webhook_url", "DISCORD_WEBHOOK_URL")
+	bindEnv(v, "rpc_policy", "SOLANA_RPC_POLICY")
+	bindEnv(v, "redis_addr", "REDIS_ADDR")
+
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
+	}
+
+	if fs != nil {
+		if err := v.BindPFlags(fs); err != nil {
+			return nil, err
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+
+	if urls := rpcURLsFromEnv(); len(urls) > 0 {
+		cfg.SolanaRPCURLs = urls
+	}
+
+	return &cfg, nil
+}
+
+func bindEnv(v *viper.Viper, key, env string) {
+	_ = v.BindEnv(key, env)
+}
+
+// rpcURLsFromEnv reads SOLANA_RPC_URLS (comma-separated) with a fallback to
+// the single-endpoint SOLANA_RPC_URL for backward compatibility.
+func rpcURLsFromEnv() []string {
+	raw := os.Getenv("SOLANA_RPC_URLS")
+	if raw == "" {
+		if single := os.Getenv("SOLANA_RPC_URL"); single != "" {
+			return []string{single}
+		}
+		return nil
+	}
+
+	var urls []string
+	for _, u := range strings.Split(raw, ",") {
+		u = strings.TrimSpace(u)
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+)
+
+// APIKeyDocContextKey is the gin context key APIKeyAuthMiddleware stores
+// the validated key's document under, for KeyedRateLimitMiddleware (and
+// handlers that need the caller's key) to read.
+const APIKeyDocContextKey = "apiKeyDoc"
+
+// APIKeyAuthMiddleware validates the x-api-key header and loads its
+// tier/limits into the gin context for KeyedRateLimitMiddleware to consume.
+func APIKeyAuthMiddleware(keys *mongostore.KeysRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader("x-api-key")
+		if apiKey == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing API key"})
+			c.Abort()
+			return
+		}
+
+		doc, err := keys.Fetch(apiKey)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing API key"})
+			c.Abort()
+			return
+		}
+
+		c.Set(APIKeyDocContextKey, doc)
+		c.Next()
+	}
+}
+
+// ValidateAPIKey is the lighter check used outside the regular gin.Context
+// pipeline (the WebSocket handshake, which can't run HandlerFunc chains the
+// same way).
+func ValidateAPIKey(keys *mongostore.KeysRepo, key string) bool {
+	return keys.Exists(key)
+}
+
+// AdminAuthMiddleware gates admin-only routes behind a separate x-admin-key,
+// independent of the regular per-client API keys.
+func AdminAuthMiddleware(adminKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminKey == "" || c.GetHeader("x-admin-key") != adminKey {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing admin key"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
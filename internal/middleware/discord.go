@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// DiscordAlerter posts short alert messages to a Discord webhook. A zero
+// value with an empty WebhookURL is a no-op, so it's safe to construct one
+// even when alerting isn't configured.
+type DiscordAlerter struct {
+	WebhookURL string
+}
+
+// Send posts message to the webhook, truncating it to Discord's practical
+// limit. No-op if WebhookURL is unset.
+func (d DiscordAlerter) Send(message string) {
+	if d.WebhookURL == "" {
+		return
+	}
+
+	// truncate if > 1900 chars
+	if len(message) > 1900 {
+		message = message[:1900] + "…"
+	}
+
+	payloadBytes, err := json.Marshal(map[string]string{"content": message})
+	if err != nil {
+		log.Println("Failed to marshal Discord payload:", err)
+		return
+	}
+
+	resp, err := http.Post(d.WebhookURL, "application/json", strings.NewReader(string(payloadBytes)))
+	if err != nil {
+		log.Println("Failed to send Discord alert:", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	log.Println("Discord response status:", resp.Status)
+}
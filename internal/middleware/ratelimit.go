@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ulule/limiter/v3"
+	memory "github.com/ulule/limiter/v3/drivers/store/memory"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+)
+
+// KeyLimiter is the in-memory hot cache enforcing rpmLimit without a Mongo
+// round-trip on every request; it wraps one ulule/limiter instance per
+// distinct rpmLimit value (tiers share an instance, custom per-key overrides
+// get their own), so each key's RPM budget is still checked against the
+// exact limit its tier or override assigns. Daily quota is enforced against
+// Mongo directly via KeysRepo.IncrementUsage since it only needs one write
+// per request and must survive restarts.
+type KeyLimiter struct {
+	mu        sync.Mutex
+	instances map[int]*limiter.Limiter
+}
+
+// NewKeyLimiter builds an empty KeyLimiter. Callers construct one per
+// router/test rather than sharing a package-level instance, so multiple
+// routers (or a test and the real server) don't cross-contaminate limits.
+func NewKeyLimiter() *KeyLimiter {
+	return &KeyLimiter{instances: make(map[int]*limiter.Limiter)}
+}
+
+func (l *KeyLimiter) instanceFor(rpmLimit int) *limiter.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if inst, ok := l.instances[rpmLimit]; ok {
+		return inst
+	}
+
+	rate, _ := limiter.NewRateFromFormatted(fmt.Sprintf("%d-M", rpmLimit))
+	inst := limiter.New(memory.NewStore(), rate)
+	l.instances[rpmLimit] = inst
+	return inst
+}
+
+// allow records a request for key and reports whether it is within rpmLimit.
+func (l *KeyLimiter) allow(ctx context.Context, key string, rpmLimit int) (bool, int64) {
+	lctx, err := l.instanceFor(rpmLimit).Get(ctx, key)
+	if err != nil {
+		// fail open: a limiter-store error shouldn't block legitimate traffic
+		return true, int64(rpmLimit)
+	}
+	return !lctx.Reached, lctx.Remaining
+}
+
+// KeyedRateLimitMiddleware enforces both the caller's RPM limit (in-memory
+// hot cache) and its daily quota (Mongo-backed, shared across instances),
+// reading the tier APIKeyAuthMiddleware loaded into the gin context.
+func KeyedRateLimitMiddleware(keys *mongostore.KeysRepo, limiter *KeyLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, ok := c.Get(APIKeyDocContextKey)
+		if !ok {
+			c.Next()
+			return
+		}
+		keyDoc := doc.(*mongostore.APIKeyDoc)
+
+		if allowed, remaining := limiter.allow(c, keyDoc.Key, keyDoc.RPMLimit); !allowed {
+			c.Header("X-RateLimit-Remaining", "0")
+			c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			c.Abort()
+			return
+		} else {
+			c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+		}
+
+		count, err := keys.IncrementUsage(keyDoc.Key)
+		if err == nil && count > keyDoc.DailyQuota {
+			resetAt := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+			c.Header("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Daily quota exceeded"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKeyLimiter_AllowsUpToRPMLimit(t *testing.T) {
+	l := NewKeyLimiter()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := l.allow(ctx, "key-a", 2); !allowed {
+			t.Fatalf("request %d: allow() = false, want true within the limit", i)
+		}
+	}
+
+	if allowed, remaining := l.allow(ctx, "key-a", 2); allowed || remaining != 0 {
+		t.Fatalf("allow() = (%v, %d), want (false, 0) once the RPM limit is exhausted", allowed, remaining)
+	}
+}
+
+func TestKeyLimiter_DistinctKeysDoNotShareBudget(t *testing.T) {
+	l := NewKeyLimiter()
+	ctx := context.Background()
+
+	// Exhaust key-a's single-request budget.
+	if allowed, _ := l.allow(ctx, "key-a", 1); !allowed {
+		t.Fatal("first request for key-a should be allowed")
+	}
+	if allowed, _ := l.allow(ctx, "key-a", 1); allowed {
+		t.Fatal("second request for key-a should be rate-limited")
+	}
+
+	// key-b shares the same rpmLimit (and so the same underlying limiter
+	// instance) but must still get its own budget.
+	if allowed, _ := l.allow(ctx, "key-b", 1); !allowed {
+		t.Fatal("key-b should not be blocked by key-a exhausting its own budget")
+	}
+}
+
+func TestKeyLimiter_RemainingDecreasesWithEachRequest(t *testing.T) {
+	l := NewKeyLimiter()
+	ctx := context.Background()
+
+	_, firstRemaining := l.allow(ctx, "key-a", 5)
+	_, secondRemaining := l.allow(ctx, "key-a", 5)
+
+	if secondRemaining >= firstRemaining {
+		t.Fatalf("remaining did not decrease: first=%d second=%d", firstRemaining, secondRemaining)
+	}
+}
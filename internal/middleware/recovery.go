@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecoveryWithDiscord recovers panics, reports them to Discord with a short
+// stack trace, and responds 500 instead of letting the connection reset.
+func RecoveryWithDiscord(alerter DiscordAlerter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				stackLines := strings.Split(string(debug.Stack()), "\n")
+				if len(stackLines) > 10 {
+					stackLines = stackLines[:10] // first 10 lines
+				}
+				msg := fmt.Sprintf("⚠️ PANIC: %v\n```%s```", r, strings.Join(stackLines, "\n"))
+				alerter.Send(msg)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,239 @@
+package mongo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// APIKeyTier describes the rate/quota budget for a tier of API keys.
+type APIKeyTier string
+
+const (
+	TierFree APIKeyTier = "free"
+	TierPro  APIKeyTier = "pro"
+)
+
+// DefaultLimitsByTier are applied when an API key document doesn't specify
+// its own rpmLimit/dailyQuota.
+var DefaultLimitsByTier = map[APIKeyTier]struct {
+	RPMLimit   int
+	DailyQuota int
+}{
+	TierFree: {RPMLimit: 10, DailyQuota: 1000},
+	TierPro:  {RPMLimit: 120, DailyQuota: 100000},
+}
+
+// APIKeyUsage tracks the request count for the current UTC day.
+type APIKeyUsage struct {
+	Date  string `bson:"date" json:"date"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// APIKeyDoc is the api_keys collection document.
+type APIKeyDoc struct {
+	Key        string      `bson:"key" json:"key"`
+	Active     bool        `bson:"active" json:"active"`
+	Tier       APIKeyTier  `bson:"tier" json:"tier"`
+	RPMLimit   int         `bson:"rpmLimit" json:"rpmLimit"`
+	DailyQuota int         `bson:"dailyQuota" json:"dailyQuota"`
+	Usage      APIKeyUsage `bson:"usage" json:"usage"`
+	CreatedAt  time.Time   `bson:"createdAt" json:"createdAt"`
+}
+
+func TodayUTC() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// KeysRepo is the repository for the api_keys collection.
+type KeysRepo struct {
+	col *mongo.Collection
+}
+
+func NewKeysRepo(s *Store) *KeysRepo {
+	return &KeysRepo{col: s.DB.Collection("api_keys")}
+}
+
+func (r *KeysRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"key": 1},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *KeysRepo) Exists(key string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return r.col.FindOne(ctx, bson.M{"key": key, "active": true}).Err() == nil
+}
+
+// InsertDefault seeds the bootstrap key configured via DEFAULT_API_KEY on
+// the pro tier, unconditionally (callers check Exists first).
+func (r *KeysRepo) InsertDefault(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	limits := DefaultLimitsByTier[TierPro]
+	_, err := r.col.InsertOne(ctx, APIKeyDoc{
+		Key:        key,
+		Active:     true,
+		Tier:       TierPro,
+		RPMLimit:   limits.RPMLimit,
+		DailyQuota: limits.DailyQuota,
+		Usage:      APIKeyUsage{Date: TodayUTC(), Count: 0},
+		CreatedAt:  time.Now(),
+	})
+	return err
+}
+
+// Fetch loads the full key document, backfilling tier defaults for keys
+// that predate this accounting (rpmLimit/dailyQuota of zero).
+func (r *KeysRepo) Fetch(key string) (*APIKeyDoc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var doc APIKeyDoc
+	if err := r.col.FindOne(ctx, bson.M{"key": key, "active": true}).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Tier == "" {
+		doc.Tier = TierFree
+	}
+	if doc.RPMLimit == 0 {
+		doc.RPMLimit = DefaultLimitsByTier[doc.Tier].RPMLimit
+	}
+	if doc.DailyQuota == 0 {
+		doc.DailyQuota = DefaultLimitsByTier[doc.Tier].DailyQuota
+	}
+
+	return &doc, nil
+}
+
+// IncrementUsage atomically bumps today's usage counter, resetting it when
+// the stored date has rolled over, and returns the count after the
+// increment.
+func (r *KeysRepo) IncrementUsage(key string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	today := TodayUTC()
+
+	res := r.col.FindOneAndUpdate(
+		ctx,
+		bson.M{"key": key, "usage.date": today},
+		bson.M{"$inc": bson.M{"usage.count": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+
+	var doc APIKeyDoc
+	if err := res.Decode(&doc); err == nil {
+		return doc.Usage.Count, nil
+	}
+
+	// No usage row for today yet (first request of the day, or a key that
+	// predates usage tracking) — reset the bucket and count this request as 1.
+	res = r.col.FindOneAndUpdate(
+		ctx,
+		bson.M{"key": key},
+		bson.M{"$set": bson.M{"usage": APIKeyUsage{Date: today, Count: 1}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	if err := res.Decode(&doc); err != nil {
+		return 0, err
+	}
+	return doc.Usage.Count, nil
+}
+
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func (r *KeysRepo) Create(tier APIKeyTier) (*APIKeyDoc, error) {
+	key, err := GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	limits := DefaultLimitsByTier[tier]
+	doc := APIKeyDoc{
+		Key:        key,
+		Active:     true,
+		Tier:       tier,
+		RPMLimit:   limits.RPMLimit,
+		DailyQuota: limits.DailyQuota,
+		Usage:      APIKeyUsage{Date: TodayUTC(), Count: 0},
+		CreatedAt:  time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := r.col.InsertOne(ctx, doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Patch applies a partial update (active/tier/rotated key) and returns the
+// new key value (unchanged unless rotate was requested).
+func (r *KeysRepo) Patch(key string, active *bool, tier APIKeyTier, rotate bool) (string, error) {
+	update := bson.M{}
+	if active != nil {
+		update["active"] = *active
+	}
+	if tier != "" {
+		limits := DefaultLimitsByTier[tier]
+		update["tier"] = tier
+		update["rpmLimit"] = limits.RPMLimit
+		update["dailyQuota"] = limits.DailyQuota
+	}
+
+	newKey := key
+	if rotate {
+		rotated, err := GenerateAPIKey()
+		if err != nil {
+			return "", err
+		}
+		newKey = rotated
+		update["key"] = newKey
+	}
+
+	if len(update) == 0 {
+		return key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	res, err := r.col.UpdateOne(ctx, bson.M{"key": key}, bson.M{"$set": update})
+	if err != nil {
+		return "", err
+	}
+	if res.MatchedCount == 0 {
+		return "", mongo.ErrNoDocuments
+	}
+	return newKey, nil
+}
+
+func (r *KeysRepo) List(ctx context.Context) ([]APIKeyDoc, error) {
+	cur, err := r.col.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var docs []APIKeyDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
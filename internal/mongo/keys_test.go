@@ -0,0 +1,25 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTodayUTC_MatchesCurrentUTCDate(t *testing.T) {
+	want := time.Now().UTC().Format("2006-01-02")
+	if got := TodayUTC(); got != want {
+		t.Fatalf("TodayUTC() = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultLimitsByTier_ProExceedsFree(t *testing.T) {
+	free := DefaultLimitsByTier[TierFree]
+	pro := DefaultLimitsByTier[TierPro]
+
+	if pro.RPMLimit <= free.RPMLimit {
+		t.Fatalf("pro RPMLimit (%d) should exceed free (%d)", pro.RPMLimit, free.RPMLimit)
+	}
+	if pro.DailyQuota <= free.DailyQuota {
+		t.Fatalf("pro DailyQuota (%d) should exceed free (%d)", pro.DailyQuota, free.DailyQuota)
+	}
+}
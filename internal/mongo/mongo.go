@@ -0,0 +1,47 @@
+// Package mongo wraps the MongoDB client and collections this service
+// persists to: API keys and payment watches.
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Store holds the connected client and the collections every repository is
+// built from.
+type Store struct {
+	Client *mongo.Client
+	DB     *mongo.Database
+}
+
+// Connect dials uri, pings it, and returns a Store scoped to dbName.
+func Connect(uri, dbName string) (*Store, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("mongo connection error: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, fmt.Errorf("mongo ping error: %w", err)
+	}
+
+	return &Store{Client: client, DB: client.Database(dbName)}, nil
+}
+
+// EnsureIndexes creates the indexes every repository relies on. Safe to run
+// repeatedly; run by the `migrate` subcommand (`serve` does not call it).
+func (s *Store) EnsureIndexes(ctx context.Context) error {
+	if err := NewKeysRepo(s).EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("api_keys indexes: %w", err)
+	}
+	if err := NewWatchesRepo(s).EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("payment_watches indexes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PaymentWatch is a registered request to be notified when an incoming
+// transfer of at least ExpectedLamports lands on Wallet.
+type PaymentWatch struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Wallet           string             `bson:"wallet" json:"wallet"`
+	ExpectedLamports uint64             `bson:"expectedLamports" json:"expectedLamports"`
+	Memo             string             `bson:"memo,omitempty" json:"memo,omitempty"`
+	CallbackURL      string             `bson:"callbackURL" json:"callbackURL"`
+	ExpiresAt        time.Time          `bson:"expiresAt" json:"expiresAt"`
+	Status           string             `bson:"status" json:"status"` // pending, fulfilled, expired
+	LastSignature    string             `bson:"lastSignature,omitempty" json:"-"`
+	APIKey           string             `bson:"apiKey" json:"-"`
+	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// WatchesRepo is the repository for the payment_watches collection.
+type WatchesRepo struct {
+	col *mongo.Collection
+}
+
+func NewWatchesRepo(s *Store) *WatchesRepo {
+	return &WatchesRepo{col: s.DB.Collection("payment_watches")}
+}
+
+func (r *WatchesRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.M{"wallet": 1, "status": 1},
+	})
+	return err
+}
+
+func (r *WatchesRepo) Insert(watch *PaymentWatch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	res, err := r.col.InsertOne(ctx, watch)
+	if err != nil {
+		return err
+	}
+	watch.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *WatchesRepo) Get(id primitive.ObjectID) (*PaymentWatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var watch PaymentWatch
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&watch); err != nil {
+		return nil, err
+	}
+	return &watch, nil
+}
+
+// ListPending returns every watch still awaiting a matching transfer, so a
+// restarted process can resume polling them instead of leaving them stuck.
+func (r *WatchesRepo) ListPending() ([]PaymentWatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	cur, err := r.col.Find(ctx, bson.M{"status": "pending"})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var watches []PaymentWatch
+	if err := cur.All(ctx, &watches); err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+func (r *WatchesRepo) MarkStatus(id primitive.ObjectID, status string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"status": status}})
+	return err
+}
+
+func (r *WatchesRepo) UpdateWatermark(id primitive.ObjectID, signature string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastSignature": signature}})
+	return err
+}
+
+// MarkFulfilled flips the watch to fulfilled and returns the document as it
+// was immediately before the update, so the caller can read CallbackURL and
+// APIKey for the notification.
+func (r *WatchesRepo) MarkFulfilled(id primitive.ObjectID) (*PaymentWatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var watch PaymentWatch
+	err := r.col.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"status": "fulfilled"}},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&watch)
+	if err != nil {
+		return nil, err
+	}
+	return &watch, nil
+}
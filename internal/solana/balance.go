@@ -0,0 +1,213 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/oncom96/solana-balance-api/internal/cache"
+)
+
+func init() {
+	// TokenBalance values pass through the cache as interface{}; the Redis
+	// backend gob-encodes them and needs the concrete type registered.
+	gobRegisterTokenBalance()
+}
+
+// TokenBalance is the per-mint SPL balance returned alongside the native
+// lamport balance.
+type TokenBalance struct {
+	Mint     string  `json:"mint"`
+	Amount   string  `json:"amount,omitempty"`
+	Decimals uint8   `json:"decimals,omitempty"`
+	UiAmount float64 `json:"uiAmount,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// BalanceService answers native and SPL token balance queries, caching
+// results and coalescing concurrent lookups for the same wallet.
+type BalanceService struct {
+	pool        *Pool
+	cache       cache.Cache
+	cacheTTL    time.Duration
+	walletLocks sync.Map // map[string]*sync.Mutex
+}
+
+func NewBalanceService(pool *Pool, c cache.Cache, cacheTTL time.Duration) *BalanceService {
+	return &BalanceService{pool: pool, cache: c, cacheTTL: cacheTTL}
+}
+
+func (s *BalanceService) lockFor(wallet string) *sync.Mutex {
+	muIface, _ := s.walletLocks.LoadOrStore(wallet, &sync.Mutex{})
+	return muIface.(*sync.Mutex)
+}
+
+// GetBalance returns wallet's native lamport balance, using the 10s cache
+// and per-wallet lock so concurrent requests for the same wallet coalesce.
+func (s *BalanceService) GetBalance(ctx context.Context, wallet string) (uint64, error) {
+	if val, found := s.cache.Get(wallet); found {
+		return val.(uint64), nil
+	}
+
+	mu := s.lockFor(wallet)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if val, found := s.cache.Get(wallet); found {
+		return val.(uint64), nil
+	}
+
+	pubKey, err := solana.PublicKeyFromBase58(wallet)
+	if err != nil {
+		return 0, fmt.Errorf("invalid wallet address: %w", err)
+	}
+
+	var balance uint64
+	err = s.pool.Call(ctx, func(ctx context.Context, client *rpc.Client) error {
+		resp, err := client.GetBalance(ctx, pubKey, rpc.CommitmentFinalized)
+		if err != nil {
+			return err
+		}
+		balance = uint64(resp.Value)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.cache.Set(wallet, balance, s.cacheTTL)
+	return balance, nil
+}
+
+// InvalidateBalance evicts wallet's cached native balance, used when a
+// WebSocket subscription observes a fresher account update.
+func (s *BalanceService) InvalidateBalance(wallet string) {
+	s.cache.Delete(wallet)
+}
+
+func tokenCacheKey(wallet, mint string) string {
+	return wallet + ":" + mint
+}
+
+// GetTokenBalances fetches the wallet's SPL token account for each
+// requested mint via GetTokenAccountsByOwner, filtered by mint. Results are
+// cached individually under a composite wallet:mint key with the same TTL
+// used for native balances, and share the per-wallet lock so concurrent
+// lookups for the same wallet coalesce.
+//
+// A mint that fails to fetch gets a TokenBalance carrying only Mint/Error
+// instead of aborting the whole request — one bad mint shouldn't blank out
+// balances already fetched for the others.
+func (s *BalanceService) GetTokenBalances(ctx context.Context, wallet string, mints []string) ([]TokenBalance, error) {
+	pubKey, err := solana.PublicKeyFromBase58(wallet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet address: %w", err)
+	}
+
+	mu := s.lockFor(wallet)
+
+	balances := make([]TokenBalance, 0, len(mints))
+	for _, mint := range mints {
+		cacheKey := tokenCacheKey(wallet, mint)
+		if val, found := s.cache.Get(cacheKey); found {
+			balances = append(balances, val.(TokenBalance))
+			continue
+		}
+
+		mu.Lock()
+		if val, found := s.cache.Get(cacheKey); found {
+			mu.Unlock()
+			balances = append(balances, val.(TokenBalance))
+			continue
+		}
+		tb, err := s.fetchTokenBalance(ctx, pubKey, mint)
+		if err == nil {
+			s.cache.Set(cacheKey, tb, s.cacheTTL)
+		}
+		mu.Unlock()
+		if err != nil {
+			balances = append(balances, TokenBalance{Mint: mint, Error: err.Error()})
+			continue
+		}
+
+		balances = append(balances, tb)
+	}
+
+	return balances, nil
+}
+
+func (s *BalanceService) fetchTokenBalance(ctx context.Context, owner solana.PublicKey, mint string) (TokenBalance, error) {
+	mintKey, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return TokenBalance{}, fmt.Errorf("invalid mint address: %w", err)
+	}
+
+	var result TokenBalance
+	err = s.pool.Call(ctx, func(ctx context.Context, client *rpc.Client) error {
+		resp, err := client.GetTokenAccountsByOwner(
+			ctx,
+			owner,
+			&rpc.GetTokenAccountsConfig{Mint: &mintKey},
+			&rpc.GetTokenAccountsOpts{Encoding: solana.EncodingJSONParsed},
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Value) == 0 {
+			result = TokenBalance{Mint: mint, Amount: "0", Decimals: 0, UiAmount: 0}
+			return nil
+		}
+
+		var parsed struct {
+			Parsed struct {
+				Info struct {
+					TokenAmount struct {
+						Amount   string  `json:"amount"`
+						Decimals uint8   `json:"decimals"`
+						UiAmount float64 `json:"uiAmount"`
+					} `json:"tokenAmount"`
+				} `json:"info"`
+			} `json:"parsed"`
+		}
+		if err := json.Unmarshal(resp.Value[0].Account.Data.GetRawJSON(), &parsed); err != nil {
+			return fmt.Errorf("failed to parse token account: %w", err)
+		}
+
+		ta := parsed.Parsed.Info.TokenAmount
+		result = TokenBalance{
+			Mint:     mint,
+			Amount:   ta.Amount,
+			Decimals: ta.Decimals,
+			UiAmount: ta.UiAmount,
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// GetTokenSupply calls GetTokenSupply for mint so clients can normalize
+// token amounts without their own RPC access.
+func (s *BalanceService) GetTokenSupply(ctx context.Context, mint string) (*rpc.GetTokenSupplyResult, error) {
+	mintKey, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint address: %w", err)
+	}
+
+	var result *rpc.GetTokenSupplyResult
+	err = s.pool.Call(ctx, func(ctx context.Context, client *rpc.Client) error {
+		resp, err := client.GetTokenSupply(ctx, mintKey, rpc.CommitmentFinalized)
+		if err != nil {
+			return err
+		}
+		result = resp
+		return nil
+	})
+	return result, err
+}
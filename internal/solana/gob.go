@@ -0,0 +1,11 @@
+package solana
+
+import "encoding/gob"
+
+// gobRegisterTokenBalance registers the concrete types this package puts
+// into cache.Cache (which stores values as interface{}), so the Redis
+// backend's gob encoding can round-trip them.
+func gobRegisterTokenBalance() {
+	gob.Register(TokenBalance{})
+	gob.Register(uint64(0))
+}
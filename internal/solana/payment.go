@@ -0,0 +1,338 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	mongostore "github.com/oncom96/solana-balance-api/internal/mongo"
+)
+
+// PaymentCallback is the payload POSTed to CallbackURL when a watch is
+// fulfilled.
+type PaymentCallback struct {
+	Wallet        string `json:"wallet"`
+	Signature     string `json:"signature"`
+	Slot          uint64 `json:"slot"`
+	Lamports      uint64 `json:"lamports"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
+// ValidateCallbackURL rejects anything but an https URL that resolves to a
+// public address, so a watch can't be used to make the server fire signed
+// requests at internal hosts (cloud metadata endpoints, admin ports, etc).
+func ValidateCallbackURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid callback URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return errors.New("callback URL must use https")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("callback URL must include a host")
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("callback URL host does not resolve: %w", err)
+	}
+	for _, ip := range ips {
+		if isDisallowedCallbackIP(ip) {
+			return fmt.Errorf("callback URL resolves to a disallowed address: %s", ip)
+		}
+	}
+
+	return nil
+}
+
+func isDisallowedCallbackIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() ||
+		ip.IsPrivate()
+}
+
+// callbackHTTPClient re-resolves and re-checks the dialed address on every
+// connection it makes (including retries), instead of trusting a lookup done
+// once at watch-registration time. Without this, a callback domain with a
+// low DNS TTL could pass ValidateCallbackURL at intake and then repoint to
+// an internal address before the watch fires.
+var callbackHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: dialPublicOnly,
+	},
+}
+
+func dialPublicOnly(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	var lastErr error
+	for _, ipAddr := range ips {
+		if isDisallowedCallbackIP(ipAddr.IP) {
+			lastErr = fmt.Errorf("refusing to dial disallowed callback address: %s", ipAddr.IP)
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ipAddr.IP.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no address found for callback host %s", host)
+	}
+	return nil, lastErr
+}
+
+// PaymentWatcher polls GetSignaturesForAddress for watched wallets until a
+// matching incoming transfer is confirmed, then fires a signed HTTP
+// callback.
+type PaymentWatcher struct {
+	pool    *Pool
+	watches *mongostore.WatchesRepo
+}
+
+func NewPaymentWatcher(pool *Pool, watches *mongostore.WatchesRepo) *PaymentWatcher {
+	return &PaymentWatcher{pool: pool, watches: watches}
+}
+
+// Start registers watch and spawns the goroutine that polls it.
+func (w *PaymentWatcher) Start(watch *mongostore.PaymentWatch) error {
+	if err := w.watches.Insert(watch); err != nil {
+		return err
+	}
+	go w.poll(watch.ID)
+	return nil
+}
+
+// Resume re-launches the poll loop for every watch still pending in Mongo.
+// Call once at startup so watches survive a process restart instead of
+// sitting orphaned until a caller happens to ask about them.
+func (w *PaymentWatcher) Resume() error {
+	watches, err := w.watches.ListPending()
+	if err != nil {
+		return err
+	}
+	for _, watch := range watches {
+		go w.poll(watch.ID)
+	}
+	if len(watches) > 0 {
+		log.Printf("✅ resumed %d pending payment watch(es)", len(watches))
+	}
+	return nil
+}
+
+func (w *PaymentWatcher) poll(id primitive.ObjectID) {
+	for {
+		watch, err := w.watches.Get(id)
+		if err != nil {
+			log.Printf("⚠️ payment watch %s disappeared: %v", id.Hex(), err)
+			return
+		}
+
+		if watch.Status != "pending" {
+			return
+		}
+		if time.Now().After(watch.ExpiresAt) {
+			if err := w.watches.MarkStatus(id, "expired"); err != nil {
+				log.Printf("⚠️ failed to mark watch %s as expired: %v", id.Hex(), err)
+			}
+			return
+		}
+
+		if fulfilled := w.checkOnce(watch); fulfilled {
+			return
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func (w *PaymentWatcher) checkOnce(watch *mongostore.PaymentWatch) bool {
+	pubKey, err := solana.PublicKeyFromBase58(watch.Wallet)
+	if err != nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	limit := 1000
+	opts := &rpc.GetSignaturesForAddressOpts{Limit: &limit}
+	if watch.LastSignature != "" {
+		if sig, err := solana.SignatureFromBase58(watch.LastSignature); err == nil {
+			opts.Until = sig
+		}
+	}
+
+	client := w.pool.Primary()
+	sigs, err := client.GetSignaturesForAddressWithOpts(ctx, pubKey, opts)
+	if err != nil {
+		log.Printf("⚠️ GetSignaturesForAddress failed for %s: %v", watch.Wallet, err)
+		return false
+	}
+	if len(sigs) == 0 {
+		return false
+	}
+
+	// sigs are newest-first; walk oldest to newest, only advancing the
+	// watermark past signatures we actually managed to inspect. A signature
+	// that fails to fetch stops the advance right there — it and everything
+	// newer get re-checked on the next poll — rather than being skipped
+	// forever, which could permanently miss the real payment.
+	newWatermark := watch.LastSignature
+
+	for i := len(sigs) - 1; i >= 0; i-- {
+		txSig := sigs[i].Signature
+		tx, err := client.GetTransaction(ctx, txSig, &rpc.GetTransactionOpts{
+			Commitment: rpc.CommitmentFinalized,
+		})
+		if err != nil || tx == nil || tx.Meta == nil {
+			log.Printf("⚠️ GetTransaction failed for %s, will retry next poll: %v", txSig, err)
+			break
+		}
+		newWatermark = txSig.String()
+
+		lamports, ok := creditToWallet(tx, watch.Wallet)
+		if !ok || lamports < watch.ExpectedLamports {
+			continue
+		}
+		if watch.Memo != "" && !transactionHasMemo(tx, watch.Memo) {
+			continue
+		}
+
+		w.fulfill(watch.ID, txSig.String(), tx.Slot, lamports)
+		_ = w.watches.UpdateWatermark(watch.ID, newWatermark)
+		return true
+	}
+
+	if newWatermark != watch.LastSignature {
+		_ = w.watches.UpdateWatermark(watch.ID, newWatermark)
+	}
+	return false
+}
+
+// creditToWallet walks the transaction's pre/post balances to find the net
+// lamport credit to wallet, if any. AccountKeys only holds the statically
+// listed accounts; a v0 transaction can also load accounts through address
+// lookup tables, which the RPC response surfaces via Meta.LoadedAddresses
+// and appends to PreBalances/PostBalances in writable-then-readonly order
+// after the static keys — so a wallet only reachable through an ALT has to
+// be searched there too.
+func creditToWallet(tx *rpc.GetTransactionResult, wallet string) (uint64, bool) {
+	decoded, err := tx.Transaction.GetTransaction()
+	if err != nil {
+		return 0, false
+	}
+
+	keys := append(append([]solana.PublicKey{}, decoded.Message.AccountKeys...),
+		append(tx.Meta.LoadedAddresses.Writable, tx.Meta.LoadedAddresses.ReadOnly...)...)
+
+	for i, key := range keys {
+		if key.String() != wallet {
+			continue
+		}
+		if i >= len(tx.Meta.PreBalances) || i >= len(tx.Meta.PostBalances) {
+			return 0, false
+		}
+		pre, post := tx.Meta.PreBalances[i], tx.Meta.PostBalances[i]
+		if post <= pre {
+			return 0, false
+		}
+		return post - pre, true
+	}
+
+	return 0, false
+}
+
+func transactionHasMemo(tx *rpc.GetTransactionResult, memo string) bool {
+	for _, m := range tx.Meta.LogMessages {
+		if bytes.Contains([]byte(m), []byte(memo)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *PaymentWatcher) fulfill(id primitive.ObjectID, signature string, slot, lamports uint64) {
+	watch, err := w.watches.MarkFulfilled(id)
+	if err != nil {
+		log.Printf("⚠️ failed to fulfill watch %s: %v", id.Hex(), err)
+		return
+	}
+
+	go sendPaymentCallback(watch.CallbackURL, watch.APIKey, PaymentCallback{
+		Wallet:        watch.Wallet,
+		Signature:     signature,
+		Slot:          slot,
+		Lamports:      lamports,
+		Confirmations: 1,
+	})
+}
+
+// sendPaymentCallback POSTs the callback payload, signed with an HMAC-SHA256
+// of the caller's API key so the receiving endpoint can verify it came from
+// us, retrying with exponential backoff.
+func sendPaymentCallback(url, apiKey string, payload PaymentCallback) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Println("⚠️ failed to marshal payment callback:", err)
+		return
+	}
+
+	mac := hmac.New(sha256.New, []byte(apiKey))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	backoff := time.Second
+	for attempt := 0; attempt < 5; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			log.Println("⚠️ failed to build callback request:", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Signature", signature)
+
+		resp, err := callbackHTTPClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return
+			}
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	log.Printf("⚠️ payment callback to %s failed after retries", url)
+}
@@ -0,0 +1,117 @@
+package solana
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+func TestValidateCallbackURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"rejects http", "http://8.8.8.8/hook", true},
+		{"rejects loopback", "https://127.0.0.1/hook", true},
+		{"rejects link-local", "https://169.254.169.254/hook", true},
+		{"rejects private", "https://10.0.0.5/hook", true},
+		{"rejects unparseable", "://not-a-url", true},
+		{"rejects missing host", "https:///hook", true},
+		{"accepts public https", "https://8.8.8.8/hook", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCallbackURL(tc.url)
+			if tc.wantErr && err == nil {
+				t.Fatalf("ValidateCallbackURL(%q) = nil, want error", tc.url)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("ValidateCallbackURL(%q) = %v, want nil", tc.url, err)
+			}
+		})
+	}
+}
+
+// txResultWithKeys builds a *rpc.GetTransactionResult whose static message
+// account keys are accountKeys, with pre/post balances and loaded addresses
+// supplied separately so tests can place the watched wallet in either.
+func txResultWithKeys(t *testing.T, accountKeys []string, pre, post []uint64, loaded rpc.LoadedAddresses) *rpc.GetTransactionResult {
+	t.Helper()
+
+	keysJSON, err := json.Marshal(accountKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txJSON := `{"signatures":[],"message":{"accountKeys":` + string(keysJSON) +
+		`,"header":{"numRequiredSignatures":0,"numReadonlySignedAccounts":0,"numReadonlyUnsignedAccounts":0},` +
+		`"recentBlockhash":"11111111111111111111111111111111","instructions":null}}`
+
+	var envelope rpc.TransactionResultEnvelope
+	if err := json.Unmarshal([]byte(txJSON), &envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	return &rpc.GetTransactionResult{
+		Slot:        1,
+		Transaction: &envelope,
+		Meta: &rpc.TransactionMeta{
+			PreBalances:     pre,
+			PostBalances:    post,
+			LoadedAddresses: loaded,
+		},
+	}
+}
+
+func TestCreditToWallet_StaticKey(t *testing.T) {
+	wallet := "11111111111111111111111111111112"
+	other := "11111111111111111111111111111113"
+	tx := txResultWithKeys(t, []string{other, wallet}, []uint64{1000, 500}, []uint64{1000, 1500}, rpc.LoadedAddresses{})
+
+	lamports, ok := creditToWallet(tx, wallet)
+	if !ok || lamports != 1000 {
+		t.Fatalf("creditToWallet() = (%d, %v), want (1000, true)", lamports, ok)
+	}
+}
+
+func TestCreditToWallet_NoCreditWhenBalanceDoesNotIncrease(t *testing.T) {
+	wallet := "11111111111111111111111111111112"
+	tx := txResultWithKeys(t, []string{wallet}, []uint64{500}, []uint64{500}, rpc.LoadedAddresses{})
+
+	if _, ok := creditToWallet(tx, wallet); ok {
+		t.Fatal("creditToWallet() = ok, want false when balance didn't increase")
+	}
+}
+
+func TestCreditToWallet_WalletNotPresent(t *testing.T) {
+	wallet := "11111111111111111111111111111112"
+	other := "11111111111111111111111111111113"
+	tx := txResultWithKeys(t, []string{other}, []uint64{500}, []uint64{1500}, rpc.LoadedAddresses{})
+
+	if _, ok := creditToWallet(tx, wallet); ok {
+		t.Fatal("creditToWallet() = ok, want false when wallet isn't in the transaction")
+	}
+}
+
+// TestCreditToWallet_LoadedViaAddressLookupTable covers a v0 transaction
+// where the watched wallet is only reachable through an address lookup
+// table rather than the static account keys.
+func TestCreditToWallet_LoadedViaAddressLookupTable(t *testing.T) {
+	static := "11111111111111111111111111111113"
+	wallet := "11111111111111111111111111111112"
+
+	loaded := rpc.LoadedAddresses{
+		Writable: solana.PublicKeySlice{solana.MustPublicKeyFromBase58(wallet)},
+	}
+	// static key at index 0, loaded writable wallet at index 1.
+	tx := txResultWithKeys(t, []string{static}, []uint64{1000, 200}, []uint64{1000, 700}, loaded)
+
+	lamports, ok := creditToWallet(tx, wallet)
+	if !ok || lamports != 500 {
+		t.Fatalf("creditToWallet() = (%d, %v), want (500, true)", lamports, ok)
+	}
+}
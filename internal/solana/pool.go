@@ -0,0 +1,295 @@
+// Package solana holds the RPC connection pool and the balance, token and
+// payment-watch domain logic that sits on top of it.
+package solana
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Policy selects how the pool picks an upstream for a given call.
+type Policy string
+
+const (
+	PolicyPrimaryFailover Policy = "primary-failover"
+	PolicyRoundRobin      Policy = "round-robin"
+	PolicyLowestLatency   Policy = "lowest-latency"
+)
+
+func PolicyFromString(s string) Policy {
+	switch Policy(s) {
+	case PolicyRoundRobin:
+		return PolicyRoundRobin
+	case PolicyLowestLatency:
+		return PolicyLowestLatency
+	default:
+		return PolicyPrimaryFailover
+	}
+}
+
+const (
+	unhealthyThreshold = 3
+	unhealthyCooldown  = 30 * time.Second
+	probeInterval      = 15 * time.Second
+	maxRetriesPerCall  = 3
+)
+
+var (
+	rpcRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rpc_requests_total",
+		Help: "Total RPC requests issued through the pool, by endpoint and result.",
+	}, []string{"endpoint", "result"})
+
+	rpcLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rpc_latency_seconds",
+		Help:    "Latency of RPC calls issued through the pool, by endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+)
+
+// endpointHealth tracks the rolling health of one upstream RPC endpoint.
+type endpointHealth struct {
+	url    string
+	client *rpc.Client
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	lastSlot            uint64
+	lastLatency         time.Duration
+}
+
+func (e *endpointHealth) healthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return time.Now().After(e.unhealthyUntil)
+}
+
+func (e *endpointHealth) recordSuccess(latency time.Duration, slot uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.consecutiveFailures = 0
+	e.lastLatency = latency
+	if slot > 0 {
+		e.lastSlot = slot
+	}
+}
+
+func (e *endpointHealth) recordFailure() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.consecutiveFailures++
+	if e.consecutiveFailures >= unhealthyThreshold {
+		e.unhealthyUntil = time.Now().Add(unhealthyCooldown)
+	}
+}
+
+func (e *endpointHealth) snapshot() gin.H {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return gin.H{
+		"url":                 e.url,
+		"healthy":             time.Now().After(e.unhealthyUntil),
+		"consecutiveFailures": e.consecutiveFailures,
+		"lastSlot":            e.lastSlot,
+		"lastLatencyMs":       e.lastLatency.Milliseconds(),
+	}
+}
+
+// Pool fans calls out across N upstream Solana RPC endpoints, retrying
+// against the next healthy endpoint on 429/5xx/timeout and routing according
+// to the configured policy.
+type Pool struct {
+	policy    Policy
+	endpoints []*endpointHealth
+
+	mu   sync.Mutex
+	next int // round-robin cursor
+}
+
+// NewPool builds a pool over urls. len(urls) must be at least 1.
+func NewPool(urls []string, policy Policy) *Pool {
+	endpoints := make([]*endpointHealth, 0, len(urls))
+	for _, u := range urls {
+		endpoints = append(endpoints, &endpointHealth{
+			url:    u,
+			client: rpc.New(u),
+		})
+	}
+	return &Pool{policy: policy, endpoints: endpoints}
+}
+
+// Primary returns the RPC client for the first configured endpoint, for
+// subsystems that talk to Solana directly rather than through Call.
+func (p *Pool) Primary() *rpc.Client {
+	return p.endpoints[0].client
+}
+
+func (p *Pool) healthyEndpoints() []*endpointHealth {
+	healthy := make([]*endpointHealth, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		if e.healthy() {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		// every endpoint is in cooldown; fall back to the full list rather
+		// than fail outright.
+		return p.endpoints
+	}
+	return healthy
+}
+
+func (p *Pool) pick() *endpointHealth {
+	candidates := p.healthyEndpoints()
+
+	switch p.policy {
+	case PolicyRoundRobin:
+		p.mu.Lock()
+		e := candidates[p.next%len(candidates)]
+		p.next++
+		p.mu.Unlock()
+		return e
+	case PolicyLowestLatency:
+		best := candidates[0]
+		for _, e := range candidates[1:] {
+			e.mu.Lock()
+			bestLatency := best.lastLatency
+			eLatency := e.lastLatency
+			e.mu.Unlock()
+			if eLatency > 0 && (bestLatency == 0 || eLatency < bestLatency) {
+				best = e
+			}
+		}
+		return best
+	default: // primary-failover
+		return candidates[0]
+	}
+}
+
+// orderedForRetry returns the endpoint to try first followed by the rest of
+// the healthy set, so a retry never reuses the endpoint that just failed.
+func (p *Pool) orderedForRetry() []*endpointHealth {
+	first := p.pick()
+	rest := make([]*endpointHealth, 0, len(p.endpoints))
+	rest = append(rest, first)
+	for _, e := range p.healthyEndpoints() {
+		if e != first {
+			rest = append(rest, e)
+		}
+	}
+	return rest
+}
+
+// Call routes fn through the pool, retrying against other healthy
+// endpoints when fn returns a retryable error.
+func (p *Pool) Call(ctx context.Context, fn func(ctx context.Context, client *rpc.Client) error) error {
+	var lastErr error
+	candidates := p.orderedForRetry()
+
+	for i, e := range candidates {
+		if i >= maxRetriesPerCall {
+			break
+		}
+
+		start := time.Now()
+		err := fn(ctx, e.client)
+		latency := time.Since(start)
+		rpcLatencySeconds.WithLabelValues(e.url).Observe(latency.Seconds())
+
+		if err == nil {
+			e.recordSuccess(latency, 0)
+			rpcRequestsTotal.WithLabelValues(e.url, "success").Inc()
+			return nil
+		}
+
+		lastErr = err
+		if !isRetryable(err) {
+			rpcRequestsTotal.WithLabelValues(e.url, "error").Inc()
+			return err
+		}
+
+		e.recordFailure()
+		rpcRequestsTotal.WithLabelValues(e.url, "retryable_error").Inc()
+	}
+
+	return lastErr
+}
+
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	retryableSubstrings := []string{"429", "500", "502", "503", "504", "timeout", "too many requests", "connection refused"}
+	for _, s := range retryableSubstrings {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProbeAll runs a GetSlot health check against every endpoint and updates
+// its rolling health. Intended to run every probeInterval in the background.
+func (p *Pool) ProbeAll(ctx context.Context) {
+	for _, e := range p.endpoints {
+		go func(e *endpointHealth) {
+			probeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			start := time.Now()
+			slot, err := e.client.GetSlot(probeCtx, rpc.CommitmentFinalized)
+			latency := time.Since(start)
+
+			if err != nil {
+				e.recordFailure()
+				rpcRequestsTotal.WithLabelValues(e.url, "probe_error").Inc()
+				return
+			}
+			e.recordSuccess(latency, slot)
+			rpcRequestsTotal.WithLabelValues(e.url, "probe_success").Inc()
+		}(e)
+	}
+}
+
+// StartProbing launches the background health-check loop. Call once at
+// startup; it runs until ctx is cancelled.
+func (p *Pool) StartProbing(ctx context.Context) {
+	ticker := time.NewTicker(probeInterval)
+	go func() {
+		defer ticker.Stop()
+		p.ProbeAll(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.ProbeAll(ctx)
+			}
+		}
+	}()
+}
+
+// StatusHandler reports the health matrix, gated by the caller (admin auth).
+func (p *Pool) StatusHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matrix := make([]gin.H, 0, len(p.endpoints))
+		for _, e := range p.endpoints {
+			matrix = append(matrix, e.snapshot())
+		}
+		c.JSON(http.StatusOK, gin.H{"policy": p.policy, "endpoints": matrix})
+	}
+}
@@ -0,0 +1,80 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"429", errors.New("429 Too Many Requests"), true},
+		{"500", errors.New("500 Internal Server Error"), true},
+		{"503", errors.New("503 Service Unavailable"), true},
+		{"timeout", errors.New("dial tcp: i/o timeout"), true},
+		{"connection refused", errors.New("connection refused"), true},
+		{"unrelated error", errors.New("invalid mint address"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryable(tc.err); got != tc.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func newTestPool(policy Policy, urls ...string) *Pool {
+	return NewPool(urls, policy)
+}
+
+func TestPool_Pick_PrimaryFailoverSkipsUnhealthy(t *testing.T) {
+	p := newTestPool(PolicyPrimaryFailover, "a", "b")
+
+	if got := p.pick(); got.url != "a" {
+		t.Fatalf("pick() = %s, want a while all endpoints are healthy", got.url)
+	}
+
+	for i := 0; i < unhealthyThreshold; i++ {
+		p.endpoints[0].recordFailure()
+	}
+
+	if got := p.pick(); got.url != "b" {
+		t.Fatalf("pick() = %s, want b once a is in cooldown", got.url)
+	}
+}
+
+func TestPool_Pick_RoundRobinCycles(t *testing.T) {
+	p := newTestPool(PolicyRoundRobin, "a", "b", "c")
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, p.pick().url)
+	}
+
+	want := []string{"a", "b", "c", "a", "b", "c"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pick() sequence = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPool_Pick_LowestLatencyPrefersFasterEndpoint(t *testing.T) {
+	p := newTestPool(PolicyLowestLatency, "slow", "fast")
+
+	p.endpoints[0].recordSuccess(200*time.Millisecond, 0)
+	p.endpoints[1].recordSuccess(10*time.Millisecond, 0)
+
+	if got := p.pick(); got.url != "fast" {
+		t.Fatalf("pick() = %s, want fast", got.url)
+	}
+}
@@ -0,0 +1,163 @@
+package solana
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// Update is pushed to subscribers whenever a watched wallet's balance
+// changes on-chain.
+type Update struct {
+	Wallet  string `json:"wallet"`
+	Balance uint64 `json:"balance"`
+	Slot    uint64 `json:"slot"`
+}
+
+// SubscriptionManager maintains a single upstream AccountSubscribe stream
+// per wallet and fans updates out to every local subscriber, so N API
+// clients watching the same wallet only cost one upstream subscription.
+type SubscriptionManager struct {
+	wsURL   string
+	balance *BalanceService
+
+	mu        sync.Mutex
+	listeners map[string][]chan Update
+	cancels   map[string]context.CancelFunc
+}
+
+func NewSubscriptionManager(wsURL string, balance *BalanceService) *SubscriptionManager {
+	return &SubscriptionManager{
+		wsURL:     wsURL,
+		balance:   balance,
+		listeners: make(map[string][]chan Update),
+		cancels:   make(map[string]context.CancelFunc),
+	}
+}
+
+// Subscribe registers a new listener for wallet and starts the upstream
+// subscription if this is the first listener. The returned func must be
+// called to unsubscribe.
+func (m *SubscriptionManager) Subscribe(wallet string) (chan Update, func()) {
+	ch := make(chan Update, 8)
+
+	m.mu.Lock()
+	_, alreadyWatching := m.listeners[wallet]
+	m.listeners[wallet] = append(m.listeners[wallet], ch)
+	m.mu.Unlock()
+
+	if !alreadyWatching {
+		m.startUpstream(wallet)
+	}
+
+	return ch, func() { m.unsubscribe(wallet, ch) }
+}
+
+func (m *SubscriptionManager) unsubscribe(wallet string, ch chan Update) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subs := m.listeners[wallet]
+	for i, c := range subs {
+		if c == ch {
+			m.listeners[wallet] = append(subs[:i], subs[i+1:]...)
+			close(ch)
+			break
+		}
+	}
+
+	if len(m.listeners[wallet]) == 0 {
+		delete(m.listeners, wallet)
+		if cancel, ok := m.cancels[wallet]; ok {
+			cancel()
+			delete(m.cancels, wallet)
+		}
+	}
+}
+
+func (m *SubscriptionManager) broadcast(wallet string, upd Update) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ch := range m.listeners[wallet] {
+		select {
+		case ch <- upd:
+		default:
+			// slow subscriber, drop the update rather than block the upstream feed
+		}
+	}
+}
+
+// startUpstream opens the AccountSubscribe stream for wallet and keeps it
+// alive with exponential backoff until every listener unsubscribes.
+func (m *SubscriptionManager) startUpstream(wallet string) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.mu.Lock()
+	m.cancels[wallet] = cancel
+	m.mu.Unlock()
+
+	go func() {
+		backoff := time.Second
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if err := m.watchAccount(ctx, wallet); err != nil {
+				log.Printf("⚠️ subscription to %s dropped: %v (retrying in %s)", wallet, err, backoff)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			backoff = time.Duration(math.Min(float64(backoff*2), float64(30*time.Second)))
+		}
+	}()
+}
+
+func (m *SubscriptionManager) watchAccount(ctx context.Context, wallet string) error {
+	pubKey, err := solana.PublicKeyFromBase58(wallet)
+	if err != nil {
+		return err
+	}
+
+	client, err := ws.Connect(ctx, m.wsURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	sub, err := client.AccountSubscribe(pubKey, rpc.CommitmentFinalized)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		got, err := sub.Recv(ctx)
+		if err != nil {
+			return err
+		}
+
+		lamports := got.Value.Lamports
+		m.balance.InvalidateBalance(wallet)
+
+		m.broadcast(wallet, Update{
+			Wallet:  wallet,
+			Balance: lamports,
+			Slot:    got.Context.Slot,
+		})
+	}
+}